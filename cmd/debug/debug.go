@@ -0,0 +1,61 @@
+// Package debug implements the `mcoctl debug` command tree, a read-only CLI
+// for inspecting the labeled secrets and PeerRefs that the controllers/common
+// package reasons about, without resorting to kubectl-jq gymnastics.
+package debug
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// outputFormat is the supported set of values for the -o/--output flag.
+type outputFormat string
+
+const (
+	outputTable outputFormat = "table"
+	outputJSON  outputFormat = "json"
+	outputYAML  outputFormat = "yaml"
+)
+
+func (f outputFormat) validate() error {
+	switch f {
+	case outputTable, outputJSON, outputYAML:
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format %q, expected one of: table, json, yaml", f)
+	}
+}
+
+// options holds flags shared by every `mcoctl debug` subcommand.
+type options struct {
+	configFlags *genericclioptions.ConfigFlags
+	output      string
+}
+
+// NewCommand returns the root `debug` cobra command, with `secrets`,
+// `peerrefs` and `mirrorpeers` wired in as subcommands.
+func NewCommand() *cobra.Command {
+	o := &options{configFlags: genericclioptions.NewConfigFlags(true)}
+
+	cmd := &cobra.Command{
+		Use:   "debug",
+		Short: "Inspect labeled secrets and PeerRefs reasoned about by the multicluster orchestrator",
+		Long: `debug connects to the hub or a managed cluster and prints structured
+output of everything the controllers/common package reasons about, to help
+on-call engineers diagnose sync issues.`,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return outputFormat(o.output).validate()
+		},
+	}
+
+	o.configFlags.AddFlags(cmd.PersistentFlags())
+	cmd.PersistentFlags().StringVarP(&o.output, "output", "o", string(outputTable), "Output format: table|json|yaml")
+
+	cmd.AddCommand(newSecretsCommand(o))
+	cmd.AddCommand(newPeerRefsCommand(o))
+	cmd.AddCommand(newMirrorPeersCommand(o))
+
+	return cmd
+}
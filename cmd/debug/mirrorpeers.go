@@ -0,0 +1,121 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	multiclusterv1alpha1 "github.com/red-hat-storage/odf-multicluster-orchestrator/api/v1alpha1"
+	"github.com/red-hat-storage/odf-multicluster-orchestrator/controllers/common"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type peerVerifyReport struct {
+	ClusterName    string `json:"clusterName"`
+	StorageCluster string `json:"storageClusterName"`
+	HubMatch       bool   `json:"hubMatch"`
+	SpokeMatch     *bool  `json:"spokeMatch,omitempty"`
+}
+
+func newMirrorPeersCommand(o *options) *cobra.Command {
+	var spokeContexts []string
+
+	cmd := &cobra.Command{
+		Use:   "mirrorpeers",
+		Short: "Inspect MirrorPeers",
+	}
+
+	verifyCmd := &cobra.Command{
+		Use:   "verify <name>",
+		Short: "Iterate a MirrorPeer's PeerRefs and confirm a matching secret exists on the hub and each spoke",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMirrorPeersVerify(o, args[0], spokeContexts)
+		},
+	}
+	verifyCmd.Flags().StringArrayVar(&spokeContexts, "spoke-context", nil,
+		"clusterName=kubeContext mapping used to also check the spoke cluster for a matching secret; may be repeated")
+
+	cmd.AddCommand(verifyCmd)
+	return cmd
+}
+
+// buildSpokeClients parses --spoke-context=clusterName=context entries into
+// a client.Client per managed cluster name, reusing the hub's kubeconfig
+// file with a different context.
+func buildSpokeClients(o *options, spokeContexts []string) (map[string]client.Client, error) {
+	clients := make(map[string]client.Client, len(spokeContexts))
+	for _, entry := range spokeContexts {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid --spoke-context %q, expected clusterName=context", entry)
+		}
+		clusterName, kubeContext := parts[0], parts[1]
+
+		spokeFlags := genericclioptions.NewConfigFlags(true)
+		spokeFlags.KubeConfig = o.configFlags.KubeConfig
+		spokeFlags.Context = &kubeContext
+
+		spokeClient, err := newClient(&options{configFlags: spokeFlags, output: o.output})
+		if err != nil {
+			return nil, fmt.Errorf("failed to build client for spoke %q: %w", clusterName, err)
+		}
+		clients[clusterName] = spokeClient
+	}
+	return clients, nil
+}
+
+func runMirrorPeersVerify(o *options, name string, spokeContexts []string) error {
+	hubClient, err := newClient(o)
+	if err != nil {
+		return err
+	}
+	spokeClients, err := buildSpokeClients(o, spokeContexts)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	var mirrorPeer multiclusterv1alpha1.MirrorPeer
+	if err := hubClient.Get(ctx, client.ObjectKey{Name: name}, &mirrorPeer); err != nil {
+		return fmt.Errorf("failed to get MirrorPeer %q: %w", name, err)
+	}
+
+	var hubSecrets corev1.SecretList
+	if err := hubClient.List(ctx, &hubSecrets); err != nil {
+		return fmt.Errorf("failed to list secrets on hub: %w", err)
+	}
+
+	reports := make([]peerVerifyReport, 0, len(mirrorPeer.Spec.Items))
+	rows := make([][]string, 0, len(mirrorPeer.Spec.Items))
+	for _, peer := range mirrorPeer.Spec.Items {
+		report := peerVerifyReport{
+			ClusterName:    peer.ClusterName,
+			StorageCluster: peer.StorageClusterRef.Namespace + "/" + peer.StorageClusterRef.Name,
+		}
+		report.HubMatch = common.FindMatchingSecretWithPeerRef(peer, hubSecrets.Items) != nil
+
+		if spokeClient, ok := spokeClients[peer.ClusterName]; ok {
+			var spokeSecrets corev1.SecretList
+			match := false
+			if err := spokeClient.List(ctx, &spokeSecrets); err == nil {
+				match = common.FindMatchingSecretWithPeerRef(peer, spokeSecrets.Items) != nil
+			}
+			report.SpokeMatch = &match
+		}
+
+		reports = append(reports, report)
+		spokeCol := "n/a"
+		if report.SpokeMatch != nil {
+			spokeCol = fmt.Sprintf("%t", *report.SpokeMatch)
+		}
+		rows = append(rows, []string{report.ClusterName, report.StorageCluster, fmt.Sprintf("%t", report.HubMatch), spokeCol})
+	}
+
+	return printRows(o.output,
+		[]string{"CLUSTER-NAME", "STORAGE-CLUSTER", "HUB-MATCH", "SPOKE-MATCH"},
+		rows, reports)
+}
@@ -0,0 +1,83 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/red-hat-storage/odf-multicluster-orchestrator/controllers/common"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type peerRefReport struct {
+	SecretNamespace string `json:"secretNamespace"`
+	SecretName      string `json:"secretName"`
+	ClusterName     string `json:"clusterName"`
+	StorageCluster  string `json:"storageClusterName"`
+	Orphan          bool   `json:"orphan"`
+	Error           string `json:"error,omitempty"`
+}
+
+func newPeerRefsCommand(o *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "peerrefs",
+		Short: "Derive PeerRefs from labeled secrets and flag orphans against known MirrorPeers",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPeerRefs(o)
+		},
+	}
+}
+
+func runPeerRefs(o *options) error {
+	c, err := newClient(o)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	var secretList corev1.SecretList
+	if err := c.List(ctx, &secretList); err != nil {
+		return fmt.Errorf("failed to list secrets: %w", err)
+	}
+
+	mirrorPeers, err := common.FetchAllMirrorPeers(ctx, c)
+	if err != nil {
+		return fmt.Errorf("failed to fetch MirrorPeers: %w", err)
+	}
+	knownPeerRefs := make(map[string]bool)
+	for _, mirrorPeer := range mirrorPeers {
+		for _, peer := range mirrorPeer.Spec.Items {
+			knownPeerRefs[peer.ClusterName+"/"+peer.StorageClusterRef.Namespace+"/"+peer.StorageClusterRef.Name] = true
+		}
+	}
+
+	reports := make([]peerRefReport, 0)
+	rows := make([][]string, 0)
+	for i := range secretList.Items {
+		secret := &secretList.Items[i]
+		peerRef, err := common.CreatePeerRefFromSecret(secret)
+		if err != nil {
+			// not every secret in the namespace is an internal secret
+			continue
+		}
+
+		key := peerRef.ClusterName + "/" + peerRef.StorageClusterRef.Namespace + "/" + peerRef.StorageClusterRef.Name
+		report := peerRefReport{
+			SecretNamespace: secret.Namespace,
+			SecretName:      secret.Name,
+			ClusterName:     peerRef.ClusterName,
+			StorageCluster:  peerRef.StorageClusterRef.Namespace + "/" + peerRef.StorageClusterRef.Name,
+			Orphan:          !knownPeerRefs[key],
+		}
+		reports = append(reports, report)
+		rows = append(rows, []string{
+			report.SecretNamespace, report.SecretName, report.ClusterName, report.StorageCluster,
+			fmt.Sprintf("%t", report.Orphan),
+		})
+	}
+
+	return printRows(o.output,
+		[]string{"SECRET-NAMESPACE", "SECRET-NAME", "CLUSTER-NAME", "STORAGE-CLUSTER", "ORPHAN"},
+		rows, reports)
+}
@@ -0,0 +1,55 @@
+package debug
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"sigs.k8s.io/yaml"
+)
+
+// printRows renders according to format: rows as a table with header as the
+// first line for "table", or raw marshaled as-is for "json"/"yaml".
+func printRows(format string, header []string, rows [][]string, raw interface{}) error {
+	switch outputFormat(format) {
+	case outputJSON:
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(raw)
+	case outputYAML:
+		out, err := yaml.Marshal(raw)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprint(os.Stdout, string(out))
+		return err
+	default:
+		return printTable(os.Stdout, header, rows)
+	}
+}
+
+func printTable(w io.Writer, header []string, rows [][]string) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	if _, err := fmt.Fprintln(tw, joinTab(header)); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if _, err := fmt.Fprintln(tw, joinTab(row)); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
+
+func joinTab(cols []string) string {
+	out := ""
+	for i, c := range cols {
+		if i > 0 {
+			out += "\t"
+		}
+		out += c
+	}
+	return out
+}
@@ -0,0 +1,111 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/red-hat-storage/odf-multicluster-orchestrator/controllers/common"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type secretReport struct {
+	Namespace        string `json:"namespace"`
+	Name             string `json:"name"`
+	Label            string `json:"label"`
+	Valid            bool   `json:"valid"`
+	Error            string `json:"error,omitempty"`
+	NamespaceField   string `json:"namespaceField,omitempty"`
+	StorageClusterID string `json:"storageClusterName,omitempty"`
+	SecretOrigin     string `json:"secretOrigin,omitempty"`
+}
+
+func newSecretsCommand(o *options) *cobra.Command {
+	var label string
+
+	cmd := &cobra.Command{
+		Use:   "secrets",
+		Short: "List labeled secrets and validate them",
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List secrets with a given label and run ValidateInternalSecret/ValidateS3Secret against each",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSecretsList(o, label)
+		},
+	}
+	listCmd.Flags().StringVar(&label, "label", "", "Secret label to filter on: BLUE|GREEN|INTERNAL")
+	_ = listCmd.MarkFlagRequired("label")
+
+	cmd.AddCommand(listCmd)
+	return cmd
+}
+
+func runSecretsList(o *options, label string) error {
+	labelType := common.SecretLabelType(label)
+	switch labelType {
+	case common.SourceLabel, common.DestinationLabel, common.InternalLabel:
+	default:
+		return fmt.Errorf("unsupported label %q, expected one of: BLUE, GREEN, INTERNAL", label)
+	}
+
+	c, err := newClient(o)
+	if err != nil {
+		return err
+	}
+
+	var secretList corev1.SecretList
+	if err := c.List(context.Background(), &secretList, client.MatchingLabels{
+		common.SecretLabelTypeKey: string(labelType),
+	}); err != nil {
+		return fmt.Errorf("failed to list secrets: %w", err)
+	}
+
+	reports := make([]secretReport, 0, len(secretList.Items))
+	rows := make([][]string, 0, len(secretList.Items))
+	for i := range secretList.Items {
+		secret := &secretList.Items[i]
+		report := secretReport{
+			Namespace: secret.Namespace,
+			Name:      secret.Name,
+			Label:     label,
+		}
+
+		var validationErr error
+		if labelType == common.InternalLabel {
+			validationErr = common.ValidateInternalSecret(secret, common.IgnoreLabel)
+		} else {
+			validationErr = common.ValidateInternalSecret(secret, labelType)
+		}
+		report.Valid = validationErr == nil
+		if validationErr != nil {
+			report.Error = validationErr.Error()
+		} else {
+			report.NamespaceField = string(secret.Data[common.NamespaceKey])
+			report.StorageClusterID = string(secret.Data[common.StorageClusterNameKey])
+			report.SecretOrigin = string(secret.Data[common.SecretOriginKey])
+			if report.SecretOrigin == common.S3Origin || report.SecretOrigin == common.S3OriginAWSSecretsManager {
+				creds, credsErr := common.ParseAWSSecretsManagerCredentials(secret.Annotations)
+				if credsErr != nil {
+					report.Valid = false
+					report.Error = fmt.Sprintf("failed to parse AWS Secrets Manager credentials: %s", credsErr)
+				} else if !common.ValidateS3Secret(secret.Data, report.SecretOrigin, creds) {
+					report.Valid = false
+					report.Error = "S3 profile data failed ValidateS3Secret"
+				}
+			}
+		}
+
+		reports = append(reports, report)
+		rows = append(rows, []string{
+			report.Namespace, report.Name, fmt.Sprintf("%t", report.Valid), report.Error,
+			report.NamespaceField, report.StorageClusterID, report.SecretOrigin,
+		})
+	}
+
+	return printRows(o.output,
+		[]string{"NAMESPACE", "NAME", "VALID", "ERROR", "NAMESPACE-KEY", "STORAGE-CLUSTER-NAME", "SECRET-ORIGIN"},
+		rows, reports)
+}
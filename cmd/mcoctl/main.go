@@ -0,0 +1,23 @@
+// Command mcoctl is an on-call CLI for the ODF multicluster orchestrator.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/red-hat-storage/odf-multicluster-orchestrator/cmd/debug"
+	"github.com/spf13/cobra"
+)
+
+func main() {
+	root := &cobra.Command{
+		Use:   "mcoctl",
+		Short: "mcoctl is a CLI for operating the ODF multicluster orchestrator",
+	}
+	root.AddCommand(debug.NewCommand())
+
+	if err := root.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
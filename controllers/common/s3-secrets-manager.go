@@ -0,0 +1,274 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+)
+
+const (
+	// S3OriginAWSSecretsManager marks an S3 profile secret whose AWS
+	// credentials are not embedded directly, but must be resolved from
+	// AWS Secrets Manager at reconcile time.
+	S3OriginAWSSecretsManager = "AWS_SECRETS_MANAGER"
+
+	// AWSSecretsManagerCredentialsAnnotationKey carries the JSON-encoded
+	// AWSSecretsManagerCredentials for an S3OriginAWSSecretsManager
+	// profile secret, read by the ramen S3 profile writer at reconcile
+	// time.
+	AWSSecretsManagerCredentialsAnnotationKey = "multicluster.odf.openshift.io/aws-secrets-manager-credentials"
+
+	// AWSSecretsManagerCacheTTL bounds how long a resolved credential set
+	// is reused before the resolver hits Secrets Manager again.
+	AWSSecretsManagerCacheTTL = 5 * time.Minute
+)
+
+// SecretValueRef points at a single key inside a remote AWS Secrets Manager
+// secret, mirroring the shape of a Kubernetes SecretKeySelector.
+type SecretValueRef struct {
+	SecretName string `json:"secretName"`
+	SecretKey  string `json:"secretKey"`
+}
+
+// AWSSecretsManagerCredentials describes how to resolve the AWS credentials
+// for an S3 profile from AWS Secrets Manager instead of reading them
+// directly off the destination secret. AccessToken is only required for
+// temporary/STS credentials; when IRSA or pod identity is in use, AccessKey
+// and AccessSecretKey may also be left unset.
+type AWSSecretsManagerCredentials struct {
+	AccessKey       *SecretValueRef `json:"accessKey,omitempty"`
+	AccessSecretKey *SecretValueRef `json:"accessSecretKey,omitempty"`
+	AccessToken     *SecretValueRef `json:"accessToken,omitempty"`
+
+	// Secrets maps additional remote secret name/key pairs onto S3 profile
+	// fields (for example s3Bucket or s3CompatibleEndpoint), for setups
+	// where those values also live in Secrets Manager rather than the
+	// destination secret.
+	Secrets []AWSSecretFieldMapping `json:"secrets,omitempty"`
+}
+
+// AWSSecretFieldMapping maps a single remote Secrets Manager name/key to the
+// S3 profile field it should populate in the resolved S3Secret map.
+type AWSSecretFieldMapping struct {
+	RemoteSecretName string `json:"remoteSecretName"`
+	RemoteSecretKey  string `json:"remoteSecretKey"`
+	S3ProfileField   string `json:"s3ProfileField"`
+}
+
+// ValidateS3SecretStatic validates an S3 profile secret whose AWS
+// credentials are embedded directly as AWS_ACCESS_KEY_ID /
+// AWS_SECRET_ACCESS_KEY.
+func ValidateS3SecretStatic(data map[string][]byte) bool {
+	_, s3ProfileName := data[S3ProfileName]
+	_, s3BucketNameOK := data[S3BucketName]
+	_, s3EndpointOk := data[S3Endpoint]
+	_, s3Region := data[S3Region]
+	_, awsAccessKeyIdOk := data[AwsAccessKeyId]
+	_, awsAccessKeyOk := data[AwsSecretAccessKey]
+	return s3ProfileName && s3BucketNameOK && s3EndpointOk && s3Region && awsAccessKeyIdOk && awsAccessKeyOk
+}
+
+// ValidateS3SecretReference validates an S3 profile secret whose AWS
+// credentials are resolved indirectly via AWS Secrets Manager. The secret
+// itself only needs to carry the non-sensitive S3 profile fields; the
+// credentials are read from creds at reconcile time instead of from data.
+func ValidateS3SecretReference(data map[string][]byte, creds *AWSSecretsManagerCredentials) bool {
+	_, s3ProfileName := data[S3ProfileName]
+	_, s3BucketNameOK := data[S3BucketName]
+	_, s3EndpointOk := data[S3Endpoint]
+	_, s3Region := data[S3Region]
+	if !(s3ProfileName && s3BucketNameOK && s3EndpointOk && s3Region) {
+		return false
+	}
+	if creds == nil {
+		return false
+	}
+	// Either both static-in-SecretsManager keys are referenced, or the
+	// caller is relying on IRSA/pod identity for the access key pair.
+	if creds.AccessKey != nil && creds.AccessSecretKey == nil {
+		return false
+	}
+	if creds.AccessSecretKey != nil && creds.AccessKey == nil {
+		return false
+	}
+	return true
+}
+
+// ParseAWSSecretsManagerCredentials reads and unmarshals the
+// AWSSecretsManagerCredentialsAnnotationKey annotation off annotations. It returns
+// nil, nil when the annotation is absent, so callers can tell "no reference
+// configured" apart from a malformed one.
+func ParseAWSSecretsManagerCredentials(annotations map[string]string) (*AWSSecretsManagerCredentials, error) {
+	raw, ok := annotations[AWSSecretsManagerCredentialsAnnotationKey]
+	if !ok || raw == "" {
+		return nil, nil
+	}
+	var creds AWSSecretsManagerCredentials
+	if err := json.Unmarshal([]byte(raw), &creds); err != nil {
+		return nil, fmt.Errorf("failed to parse %s annotation: %w", AWSSecretsManagerCredentialsAnnotationKey, err)
+	}
+	return &creds, nil
+}
+
+// ValidateS3Secret validates an S3 profile secret according to its
+// SecretOriginKey. Static secrets (origin S3) are validated by
+// ValidateS3SecretStatic; secrets originating from AWS Secrets Manager are
+// validated by ValidateS3SecretReference against creds.
+func ValidateS3Secret(data map[string][]byte, origin string, creds *AWSSecretsManagerCredentials) bool {
+	switch origin {
+	case S3OriginAWSSecretsManager:
+		return ValidateS3SecretReference(data, creds)
+	default:
+		return ValidateS3SecretStatic(data)
+	}
+}
+
+// secretsManagerAPI is the subset of the Secrets Manager client used by the
+// resolver, kept narrow so tests can fake it.
+type secretsManagerAPI interface {
+	GetSecretValue(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error)
+}
+
+type cachedCredential struct {
+	value     []byte
+	fetchedAt time.Time
+}
+
+// AWSSecretsManagerResolver resolves an AWSSecretsManagerCredentials
+// reference into the plain S3Secret data map the rest of the ramen S3
+// profile writer already understands, caching values for
+// AWSSecretsManagerCacheTTL so reconciles don't hammer Secrets Manager.
+type AWSSecretsManagerResolver struct {
+	client secretsManagerAPI
+
+	mu    sync.Mutex
+	cache map[string]cachedCredential
+}
+
+// NewAWSSecretsManagerResolver builds a resolver using the default AWS
+// config credential chain, which transparently supports IRSA and EC2/pod
+// identity when no static keys are configured in the environment.
+func NewAWSSecretsManagerResolver(ctx context.Context) (*AWSSecretsManagerResolver, error) {
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	return &AWSSecretsManagerResolver{
+		client: secretsmanager.NewFromConfig(cfg),
+		cache:  make(map[string]cachedCredential),
+	}, nil
+}
+
+// Resolve fetches every referenced value in creds and overlays the result
+// onto a copy of data, producing the final S3Secret map. Values already
+// present in data are left untouched unless creds resolves the same field:
+// AccessKey, AccessSecretKey and AccessToken overwrite AWS_ACCESS_KEY_ID,
+// AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN, and each mapping in
+// creds.Secrets overwrites its S3ProfileField.
+func (r *AWSSecretsManagerResolver) Resolve(ctx context.Context, data map[string][]byte, creds *AWSSecretsManagerCredentials) (map[string][]byte, error) {
+	if creds == nil {
+		return nil, errors.New("provided AWS Secrets Manager credentials are 'nil'")
+	}
+
+	resolved := make(map[string][]byte, len(data))
+	for k, v := range data {
+		resolved[k] = v
+	}
+
+	if creds.AccessKey != nil {
+		val, err := r.get(ctx, creds.AccessKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve access key: %w", err)
+		}
+		resolved[AwsAccessKeyId] = val
+	}
+	if creds.AccessSecretKey != nil {
+		val, err := r.get(ctx, creds.AccessSecretKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve access secret key: %w", err)
+		}
+		resolved[AwsSecretAccessKey] = val
+	}
+	if creds.AccessToken != nil {
+		val, err := r.get(ctx, creds.AccessToken)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve access token: %w", err)
+		}
+		resolved["AWS_SESSION_TOKEN"] = val
+	}
+
+	for _, mapping := range creds.Secrets {
+		val, err := r.get(ctx, &SecretValueRef{SecretName: mapping.RemoteSecretName, SecretKey: mapping.RemoteSecretKey})
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve mapping for %s: %w", mapping.S3ProfileField, err)
+		}
+		resolved[mapping.S3ProfileField] = val
+	}
+
+	return resolved, nil
+}
+
+// get returns the cached value for ref when it is still within
+// AWSSecretsManagerCacheTTL, otherwise it fetches and caches a fresh one.
+func (r *AWSSecretsManagerResolver) get(ctx context.Context, ref *SecretValueRef) ([]byte, error) {
+	cacheKey := ref.SecretName + "/" + ref.SecretKey
+
+	r.mu.Lock()
+	cached, ok := r.cache[cacheKey]
+	r.mu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < AWSSecretsManagerCacheTTL {
+		return cached.value, nil
+	}
+
+	out, err := r.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(ref.SecretName),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var raw []byte
+	if out.SecretBinary != nil {
+		raw = out.SecretBinary
+	} else if out.SecretString != nil {
+		raw = []byte(*out.SecretString)
+	}
+	if raw == nil {
+		return nil, fmt.Errorf("secret %q has no value", ref.SecretName)
+	}
+
+	val, err := extractSecretKey(raw, ref.SecretKey)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.cache[cacheKey] = cachedCredential{value: val, fetchedAt: time.Now()}
+	r.mu.Unlock()
+
+	return val, nil
+}
+
+// extractSecretKey pulls a single key out of a JSON-encoded secret payload,
+// or returns raw as-is when key is empty (a plain-value secret).
+func extractSecretKey(raw []byte, key string) ([]byte, error) {
+	if key == "" {
+		return raw, nil
+	}
+	var payload map[string]string
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return nil, fmt.Errorf("secret value is not a JSON object and a key was requested: %w", err)
+	}
+	val, ok := payload[key]
+	if !ok {
+		return nil, fmt.Errorf("key %q not found in secret value", key)
+	}
+	return []byte(val), nil
+}
@@ -0,0 +1,114 @@
+package common
+
+import "testing"
+
+func s3ReferenceData() map[string][]byte {
+	return map[string][]byte{
+		S3ProfileName: []byte("profile"),
+		S3BucketName:  []byte("bucket"),
+		S3Endpoint:    []byte("endpoint"),
+		S3Region:      []byte("region"),
+	}
+}
+
+func TestValidateS3SecretReference(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  map[string][]byte
+		creds *AWSSecretsManagerCredentials
+		want  bool
+	}{
+		{
+			name:  "nil creds is invalid",
+			data:  s3ReferenceData(),
+			creds: nil,
+			want:  false,
+		},
+		{
+			name:  "missing S3 profile fields is invalid even with creds",
+			data:  map[string][]byte{S3ProfileName: []byte("profile")},
+			creds: &AWSSecretsManagerCredentials{},
+			want:  false,
+		},
+		{
+			name:  "IRSA/pod-identity style creds with no access key pair is valid",
+			data:  s3ReferenceData(),
+			creds: &AWSSecretsManagerCredentials{},
+			want:  true,
+		},
+		{
+			name: "access key present without access secret key is invalid",
+			data: s3ReferenceData(),
+			creds: &AWSSecretsManagerCredentials{
+				AccessKey: &SecretValueRef{SecretName: "creds", SecretKey: "access-key"},
+			},
+			want: false,
+		},
+		{
+			name: "access secret key present without access key is invalid",
+			data: s3ReferenceData(),
+			creds: &AWSSecretsManagerCredentials{
+				AccessSecretKey: &SecretValueRef{SecretName: "creds", SecretKey: "secret-key"},
+			},
+			want: false,
+		},
+		{
+			name: "both access key and access secret key present is valid",
+			data: s3ReferenceData(),
+			creds: &AWSSecretsManagerCredentials{
+				AccessKey:       &SecretValueRef{SecretName: "creds", SecretKey: "access-key"},
+				AccessSecretKey: &SecretValueRef{SecretName: "creds", SecretKey: "secret-key"},
+			},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidateS3SecretReference(tt.data, tt.creds); got != tt.want {
+				t.Errorf("ValidateS3SecretReference() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateS3Secret(t *testing.T) {
+	staticData := map[string][]byte{
+		S3ProfileName:      []byte("profile"),
+		S3BucketName:       []byte("bucket"),
+		S3Endpoint:         []byte("endpoint"),
+		S3Region:           []byte("region"),
+		AwsAccessKeyId:     []byte("id"),
+		AwsSecretAccessKey: []byte("secret"),
+	}
+
+	if !ValidateS3Secret(staticData, S3Origin, nil) {
+		t.Error("expected static S3 origin secret to be valid with nil creds")
+	}
+	if ValidateS3Secret(s3ReferenceData(), S3OriginAWSSecretsManager, nil) {
+		t.Error("expected AWS Secrets Manager origin secret with nil creds to be invalid")
+	}
+}
+
+func TestParseAWSSecretsManagerCredentials(t *testing.T) {
+	creds, err := ParseAWSSecretsManagerCredentials(nil)
+	if err != nil || creds != nil {
+		t.Fatalf("expected (nil, nil) for missing annotation, got (%v, %v)", creds, err)
+	}
+
+	annotations := map[string]string{
+		AWSSecretsManagerCredentialsAnnotationKey: `{"accessKey":{"secretName":"creds","secretKey":"access-key"}}`,
+	}
+	creds, err = ParseAWSSecretsManagerCredentials(annotations)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if creds == nil || creds.AccessKey == nil || creds.AccessKey.SecretName != "creds" {
+		t.Fatalf("unexpected parsed credentials: %+v", creds)
+	}
+
+	annotations = map[string]string{AWSSecretsManagerCredentialsAnnotationKey: "not-json"}
+	if _, err := ParseAWSSecretsManagerCredentials(annotations); err == nil {
+		t.Fatal("expected error parsing malformed annotation")
+	}
+}
@@ -0,0 +1,44 @@
+package common
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// LastRotatedAnnotationKey records the timestamp (RFC3339) a generated
+// secret was last (re)generated, so a SecretHelper can decide when it has
+// aged past its maximum allowed lifetime.
+const LastRotatedAnnotationKey = "odf.openshift.io/last-rotated"
+
+// SecretObserveState is the outcome of observing a generated secret against
+// a SecretHelper's validity rules.
+type SecretObserveState string
+
+const (
+	// SecretObserveStateValid means the secret exists and passes IsValid.
+	SecretObserveStateValid SecretObserveState = "Valid"
+	// SecretObserveStateMissing means the secret does not exist and must
+	// be generated.
+	SecretObserveStateMissing SecretObserveState = "Missing"
+	// SecretObserveStateNeedsRotation means the secret exists but fails
+	// IsValid, e.g. wrong length, missing keys, or past its max age.
+	SecretObserveStateNeedsRotation SecretObserveState = "NeedsRotation"
+)
+
+// SecretHelper generates and validates the material backing a single kind
+// of generated secret (for example the symmetric key that protects
+// SecretDataKey inside a MirrorPeerSecret). Controllers that own such a
+// secret's lifecycle reconcile against a SecretHelper instead of
+// hard-coding generation/validation logic inline.
+type SecretHelper interface {
+	// Generate returns a brand new secret object for name, populated with
+	// freshly generated material. The caller is responsible for creating
+	// or updating it against the API server.
+	Generate(name types.NamespacedName) (*corev1.Secret, error)
+	// IsValid reports whether secret still satisfies this helper's rules
+	// (key length, required data keys, max-age annotation).
+	IsValid(secret *corev1.Secret) bool
+	// Observe classifies secret relative to this helper's rules without
+	// mutating it.
+	Observe(secret *corev1.Secret) SecretObserveState
+}
@@ -12,6 +12,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
@@ -32,6 +33,11 @@ const (
 	SecretOriginKey                       = "secret-origin"
 	MirrorPeerSecret                      = "mirrorpeersecret"
 
+	// SecretDataHashAnnotationKey is bumped whenever a Source/Destination
+	// secret's payload is rotated, so a watch update on that secret is
+	// guaranteed to carry a real, observable change.
+	SecretDataHashAnnotationKey = "multicluster.odf.openshift.io/secret-data-hash"
+
 	// rook
 	RookOrigin = "rook"
 
@@ -137,15 +143,9 @@ func ValidateDestinationSecret(sourceSecret *corev1.Secret) error {
 	return ValidateInternalSecret(sourceSecret, DestinationLabel)
 }
 
-func ValidateS3Secret(data map[string][]byte) bool {
-	_, s3ProfileName := data[S3ProfileName]
-	_, s3BucketNameOK := data[S3BucketName]
-	_, s3EndpointOk := data[S3Endpoint]
-	_, s3Region := data[S3Region]
-	_, awsAccessKeyIdOk := data[AwsAccessKeyId]
-	_, awsAccessKeyOk := data[AwsSecretAccessKey]
-	return s3ProfileName && s3BucketNameOK && s3EndpointOk && s3Region && awsAccessKeyIdOk && awsAccessKeyOk
-}
+// ValidateS3Secret, ValidateS3SecretStatic and ValidateS3SecretReference are
+// defined in s3-secrets-manager.go, since the AWS_SECRETS_MANAGER origin
+// needs the AWSSecretsManagerCredentials type declared there.
 
 // createInternalSecret a common function to create any type secret
 func createInternalSecret(secretNameAndNamespace types.NamespacedName,
@@ -159,6 +159,9 @@ func createInternalSecret(secretNameAndNamespace types.NamespacedName,
 			Labels: map[string]string{
 				SecretLabelTypeKey: string(secretType),
 			},
+			Annotations: map[string]string{
+				SecretDataHashAnnotationKey: CreateUniqueName(string(secretData)),
+			},
 		},
 		Type: corev1.SecretTypeOpaque,
 		Data: map[string][]byte{
@@ -208,6 +211,84 @@ func CreateUniqueSecretName(managedCluster, storageClusterNamespace, storageClus
 	return CreateUniqueName(managedCluster, storageClusterNamespace, storageClusterName)[0:39]
 }
 
+// SecretNameOverrideAnnotationKey lets an operator pre-provision a secret
+// under a chosen name instead of the deterministic hash CreateUniqueSecretName
+// produces, e.g. when integrating with an external secret manager or GitOps
+// pipeline that must know the name ahead of time. Its value on a MirrorPeer
+// is a comma-separated list of "clusterName=secretName" pairs, one per
+// PeerRef that needs an override.
+const SecretNameOverrideAnnotationKey = "multicluster.odf.openshift.io/secret-name-override"
+
+// secretNameOverrides parses the SecretNameOverrideAnnotationKey annotation
+// into a map of managed cluster name to overridden secret name. It returns
+// nil when the annotation is absent or empty.
+func secretNameOverrides(annotations map[string]string) map[string]string {
+	raw, ok := annotations[SecretNameOverrideAnnotationKey]
+	if !ok || strings.TrimSpace(raw) == "" {
+		return nil
+	}
+	overrides := make(map[string]string)
+	for _, pair := range strings.Split(raw, ",") {
+		kv := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+			continue
+		}
+		overrides[kv[0]] = kv[1]
+	}
+	return overrides
+}
+
+// validateSecretNameOverride checks name against the same constraints a
+// Secret's metadata.name must satisfy: a DNS-1123 subdomain no longer than
+// 253 characters.
+func validateSecretNameOverride(name string) error {
+	if len(name) == 0 || len(name) > 253 {
+		return fmt.Errorf("secret name override %q must be non-empty and at most 253 characters", name)
+	}
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return fmt.Errorf("secret name override %q is not a valid DNS-1123 subdomain: %s", name, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// ResolveSecretName returns the secret name to use for peerRef's
+// Destination secret mirroring sourcePeerRef's storage cluster: the
+// SecretNameOverrideAnnotationKey override for peerRef.ClusterName when
+// mirrorPeer carries one, validated and checked for collisions against
+// every other peer's override, or the deterministic CreateUniqueSecretName
+// hash over peerRef.ClusterName and sourcePeerRef.StorageClusterRef
+// otherwise. sourcePeerRef must be kept in the hash input: it identifies
+// which source storage cluster the destination secret mirrors, so two
+// different sources fanning in to the same destination cluster don't
+// collide on the same secret name/namespace.
+func ResolveSecretName(peerRef, sourcePeerRef multiclusterv1alpha1.PeerRef, mirrorPeer multiclusterv1alpha1.MirrorPeer) (string, error) {
+	overrides := secretNameOverrides(mirrorPeer.Annotations)
+	name, ok := overrides[peerRef.ClusterName]
+	if !ok {
+		return HashedSecretName(peerRef, sourcePeerRef), nil
+	}
+
+	if err := validateSecretNameOverride(name); err != nil {
+		return "", fmt.Errorf("MirrorPeer %q: %w", mirrorPeer.Name, err)
+	}
+	for otherCluster, otherName := range overrides {
+		if otherCluster != peerRef.ClusterName && otherName == name {
+			return "", fmt.Errorf("MirrorPeer %q: secret name override %q is used by both cluster %q and %q",
+				mirrorPeer.Name, name, peerRef.ClusterName, otherCluster)
+		}
+	}
+	return name, nil
+}
+
+// HashedSecretName returns the deterministic CreateUniqueSecretName for a
+// Destination secret on peerRef.ClusterName mirroring
+// sourcePeerRef.StorageClusterRef, regardless of any
+// SecretNameOverrideAnnotationKey override. It lets callers detect a stale
+// secret left behind by a migration onto an override.
+func HashedSecretName(peerRef, sourcePeerRef multiclusterv1alpha1.PeerRef) string {
+	return CreateUniqueSecretName(peerRef.ClusterName, sourcePeerRef.StorageClusterRef.Namespace, sourcePeerRef.StorageClusterRef.Name)
+}
+
 // CreatePeerRefFromSecret function creates a 'PeerRef' object
 // from the internal secret details
 func CreatePeerRefFromSecret(secret *corev1.Secret) (multiclusterv1alpha1.PeerRef, error) {
@@ -0,0 +1,113 @@
+package common
+
+import (
+	"testing"
+
+	multiclusterv1alpha1 "github.com/red-hat-storage/odf-multicluster-orchestrator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func peerRef(clusterName, storageNamespace, storageName string) multiclusterv1alpha1.PeerRef {
+	return multiclusterv1alpha1.PeerRef{
+		ClusterName: clusterName,
+		StorageClusterRef: multiclusterv1alpha1.StorageClusterRef{
+			Namespace: storageNamespace,
+			Name:      storageName,
+		},
+	}
+}
+
+func TestResolveSecretNameWithoutOverride(t *testing.T) {
+	dest := peerRef("cluster-b", "", "")
+	source := peerRef("cluster-a", "openshift-storage", "ocs-storagecluster")
+	mirrorPeer := multiclusterv1alpha1.MirrorPeer{ObjectMeta: metav1.ObjectMeta{Name: "mp"}}
+
+	name, err := ResolveSecretName(dest, source, mirrorPeer)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name != HashedSecretName(dest, source) {
+		t.Errorf("expected the deterministic hash when no override is set, got %q", name)
+	}
+}
+
+func TestResolveSecretNameWithOverride(t *testing.T) {
+	dest := peerRef("cluster-b", "", "")
+	source := peerRef("cluster-a", "openshift-storage", "ocs-storagecluster")
+	mirrorPeer := multiclusterv1alpha1.MirrorPeer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "mp",
+			Annotations: map[string]string{SecretNameOverrideAnnotationKey: "cluster-b=my-preprovisioned-secret"},
+		},
+	}
+
+	name, err := ResolveSecretName(dest, source, mirrorPeer)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name != "my-preprovisioned-secret" {
+		t.Errorf("expected override name, got %q", name)
+	}
+}
+
+func TestResolveSecretNameInvalidOverride(t *testing.T) {
+	dest := peerRef("cluster-b", "", "")
+	source := peerRef("cluster-a", "openshift-storage", "ocs-storagecluster")
+	mirrorPeer := multiclusterv1alpha1.MirrorPeer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "mp",
+			Annotations: map[string]string{SecretNameOverrideAnnotationKey: "cluster-b=Not_A_Valid-Name!"},
+		},
+	}
+
+	if _, err := ResolveSecretName(dest, source, mirrorPeer); err == nil {
+		t.Fatal("expected an error for an invalid DNS-1123 override")
+	}
+}
+
+func TestResolveSecretNameCollidingOverrides(t *testing.T) {
+	destA := peerRef("cluster-b", "", "")
+	destC := peerRef("cluster-c", "", "")
+	source := peerRef("cluster-a", "openshift-storage", "ocs-storagecluster")
+	mirrorPeer := multiclusterv1alpha1.MirrorPeer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "mp",
+			Annotations: map[string]string{
+				SecretNameOverrideAnnotationKey: "cluster-b=shared-name,cluster-c=shared-name",
+			},
+		},
+	}
+
+	if _, err := ResolveSecretName(destA, source, mirrorPeer); err == nil {
+		t.Fatal("expected a collision error when two peers share the same override")
+	}
+	if _, err := ResolveSecretName(destC, source, mirrorPeer); err == nil {
+		t.Fatal("expected a collision error when two peers share the same override")
+	}
+}
+
+// TestResolveSecretNameFanIn guards against a regression where the
+// destination secret name/namespace was derived only from the destination
+// peer, so two different source MirrorPeers fanning their Destination
+// secret into the same destination cluster collided on the same secret.
+func TestResolveSecretNameFanIn(t *testing.T) {
+	dest := peerRef("cluster-shared-destination", "", "")
+	sourceOne := peerRef("cluster-source-1", "openshift-storage", "ocs-storagecluster")
+	sourceTwo := peerRef("cluster-source-2", "openshift-storage", "ocs-storagecluster")
+
+	mirrorPeerOne := multiclusterv1alpha1.MirrorPeer{ObjectMeta: metav1.ObjectMeta{Name: "mp-1"}}
+	mirrorPeerTwo := multiclusterv1alpha1.MirrorPeer{ObjectMeta: metav1.ObjectMeta{Name: "mp-2"}}
+
+	nameOne, err := ResolveSecretName(dest, sourceOne, mirrorPeerOne)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	nameTwo, err := ResolveSecretName(dest, sourceTwo, mirrorPeerTwo)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if nameOne == nameTwo {
+		t.Fatalf("expected distinct secret names for different sources fanning into the same destination cluster, both got %q", nameOne)
+	}
+}
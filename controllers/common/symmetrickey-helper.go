@@ -0,0 +1,106 @@
+package common
+
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// SymmetricKeyDataKey is the data key holding the generated symmetric
+	// material inside a MirrorPeer bootstrap secret.
+	SymmetricKeyDataKey = "symmetric-key"
+
+	// defaultSymmetricKeyLength is the length, in bytes, of the
+	// generated key: 32 bytes gives AES-256/HMAC-SHA256 sized material.
+	defaultSymmetricKeyLength = 32
+
+	// defaultSymmetricKeyMaxAge bounds how long a symmetric key is
+	// trusted before the controller rotates it.
+	defaultSymmetricKeyMaxAge = 30 * 24 * time.Hour
+)
+
+// SymmetricKeyHelper is a SecretHelper that generates and validates the
+// shared symmetric material (encryption/HMAC key) used to protect
+// SecretDataKey inside a MirrorPeerSecret.
+type SymmetricKeyHelper struct {
+	// KeyLength is the length, in bytes, of generated keys. Defaults to
+	// defaultSymmetricKeyLength when zero.
+	KeyLength int
+	// MaxAge bounds how long a key is valid before it must be rotated.
+	// Defaults to defaultSymmetricKeyMaxAge when zero.
+	MaxAge time.Duration
+}
+
+var _ SecretHelper = &SymmetricKeyHelper{}
+
+func (h *SymmetricKeyHelper) keyLength() int {
+	if h.KeyLength > 0 {
+		return h.KeyLength
+	}
+	return defaultSymmetricKeyLength
+}
+
+func (h *SymmetricKeyHelper) maxAge() time.Duration {
+	if h.MaxAge > 0 {
+		return h.MaxAge
+	}
+	return defaultSymmetricKeyMaxAge
+}
+
+// Generate returns a new secret for name containing cryptographically
+// random bytes of length h.keyLength(), stamped with
+// LastRotatedAnnotationKey.
+func (h *SymmetricKeyHelper) Generate(name types.NamespacedName) (*corev1.Secret, error) {
+	key := make([]byte, h.keyLength())
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate symmetric key: %w", err)
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.Name,
+			Namespace: name.Namespace,
+			Annotations: map[string]string{
+				LastRotatedAnnotationKey: time.Now().UTC().Format(time.RFC3339),
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			SymmetricKeyDataKey: key,
+		},
+	}, nil
+}
+
+// IsValid reports whether secret carries a key of the expected length and
+// has not aged past h.maxAge().
+func (h *SymmetricKeyHelper) IsValid(secret *corev1.Secret) bool {
+	return h.Observe(secret) == SecretObserveStateValid
+}
+
+// Observe classifies secret as missing, valid, or needing rotation.
+func (h *SymmetricKeyHelper) Observe(secret *corev1.Secret) SecretObserveState {
+	if secret == nil {
+		return SecretObserveStateMissing
+	}
+	key, ok := secret.Data[SymmetricKeyDataKey]
+	if !ok || len(key) != h.keyLength() {
+		return SecretObserveStateNeedsRotation
+	}
+	lastRotated, ok := secret.Annotations[LastRotatedAnnotationKey]
+	if !ok {
+		return SecretObserveStateNeedsRotation
+	}
+	rotatedAt, err := time.Parse(time.RFC3339, lastRotated)
+	if err != nil {
+		return SecretObserveStateNeedsRotation
+	}
+	if time.Since(rotatedAt) > h.maxAge() {
+		return SecretObserveStateNeedsRotation
+	}
+	return SecretObserveStateValid
+}
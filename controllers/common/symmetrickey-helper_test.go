@@ -0,0 +1,105 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestSymmetricKeyHelperObserve(t *testing.T) {
+	helper := &SymmetricKeyHelper{KeyLength: 16, MaxAge: time.Hour}
+
+	validSecret := func() *corev1.Secret {
+		return &corev1.Secret{
+			Data: map[string][]byte{SymmetricKeyDataKey: make([]byte, 16)},
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					LastRotatedAnnotationKey: time.Now().Add(-time.Minute).UTC().Format(time.RFC3339),
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		secret *corev1.Secret
+		want   SecretObserveState
+	}{
+		{
+			name:   "nil secret is missing",
+			secret: nil,
+			want:   SecretObserveStateMissing,
+		},
+		{
+			name: "wrong key length needs rotation",
+			secret: &corev1.Secret{
+				Data: map[string][]byte{SymmetricKeyDataKey: make([]byte, 8)},
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{LastRotatedAnnotationKey: time.Now().UTC().Format(time.RFC3339)},
+				},
+			},
+			want: SecretObserveStateNeedsRotation,
+		},
+		{
+			name: "missing last-rotated annotation needs rotation",
+			secret: &corev1.Secret{
+				Data: map[string][]byte{SymmetricKeyDataKey: make([]byte, 16)},
+			},
+			want: SecretObserveStateNeedsRotation,
+		},
+		{
+			name: "unparseable last-rotated annotation needs rotation",
+			secret: &corev1.Secret{
+				Data: map[string][]byte{SymmetricKeyDataKey: make([]byte, 16)},
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{LastRotatedAnnotationKey: "not-a-timestamp"},
+				},
+			},
+			want: SecretObserveStateNeedsRotation,
+		},
+		{
+			name: "past max age needs rotation",
+			secret: &corev1.Secret{
+				Data: map[string][]byte{SymmetricKeyDataKey: make([]byte, 16)},
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						LastRotatedAnnotationKey: time.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339),
+					},
+				},
+			},
+			want: SecretObserveStateNeedsRotation,
+		},
+		{
+			name:   "fresh key of the right length is valid",
+			secret: validSecret(),
+			want:   SecretObserveStateValid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := helper.Observe(tt.secret); got != tt.want {
+				t.Errorf("Observe() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSymmetricKeyHelperGenerate(t *testing.T) {
+	helper := &SymmetricKeyHelper{}
+	name := types.NamespacedName{Namespace: "ns", Name: "key"}
+
+	secret, err := helper.Generate(name)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(secret.Data[SymmetricKeyDataKey]) != defaultSymmetricKeyLength {
+		t.Errorf("expected generated key of length %d, got %d", defaultSymmetricKeyLength, len(secret.Data[SymmetricKeyDataKey]))
+	}
+	if !helper.IsValid(secret) {
+		t.Error("expected freshly generated secret to be valid")
+	}
+}
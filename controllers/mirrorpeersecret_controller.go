@@ -0,0 +1,218 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	multiclusterv1alpha1 "github.com/red-hat-storage/odf-multicluster-orchestrator/api/v1alpha1"
+	"github.com/red-hat-storage/odf-multicluster-orchestrator/controllers/common"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"k8s.io/client-go/tools/record"
+)
+
+// MirrorPeerSecretReconciler watches Source and Internal secrets on the hub
+// and keeps every peer's Destination secret in sync, including when the
+// SecretDataKey payload rotates (e.g. a Ceph mon key or S3 key rotation on
+// the source cluster).
+type MirrorPeerSecretReconciler struct {
+	HubClient client.Client
+	Scheme    *runtime.Scheme
+	Logger    logr.Logger
+	Recorder  record.EventRecorder
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *MirrorPeerSecretReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Secret{}).
+		WithEventFilter(r.secretChangedPredicate()).
+		Complete(r)
+}
+
+// secretChangedPredicate narrows common.SourceOrDestinationPredicate's
+// update check: an update event only passes once it has also been confirmed
+// label-stable, and only when its SecretDataHashAnnotationKey annotation
+// changed or updateInternalSecret reports the rotated secret's payload or
+// peer identity actually changed. Without the second check every
+// label-stable update (including metadata-only ones) would already pass
+// through SourceOrDestinationPredicate.Update on its own, making the diff
+// in updateInternalSecret redundant.
+func (r *MirrorPeerSecretReconciler) secretChangedPredicate() predicate.Funcs {
+	base := common.SourceOrDestinationPredicate
+	return predicate.Funcs{
+		CreateFunc:  base.Create,
+		DeleteFunc:  base.Delete,
+		GenericFunc: base.Generic,
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if !base.Update(e) {
+				return false
+			}
+			oldSecret, ok := e.ObjectOld.(*corev1.Secret)
+			if !ok {
+				return false
+			}
+			newSecret, ok := e.ObjectNew.(*corev1.Secret)
+			if !ok {
+				return false
+			}
+			if oldSecret.Annotations[common.SecretDataHashAnnotationKey] != newSecret.Annotations[common.SecretDataHashAnnotationKey] {
+				return true
+			}
+			changed, err := updateInternalSecret(oldSecret, newSecret)
+			if err != nil {
+				return false
+			}
+			return changed
+		},
+	}
+}
+
+// updateInternalSecret diffs an old and new internal secret and reports
+// whether the payload or the peer it belongs to changed, i.e. whether
+// SecretDataKey, SecretOriginKey or the derived PeerRef differ. It is used
+// to decide whether a rotated Source/Destination secret needs to be
+// propagated to its peers, rather than relying on a deep comparison of the
+// two objects.
+func updateInternalSecret(oldSecret, newSecret *corev1.Secret) (bool, error) {
+	oldPeerRef, err := common.CreatePeerRefFromSecret(oldSecret)
+	if err != nil {
+		return false, fmt.Errorf("failed to derive PeerRef from old secret: %w", err)
+	}
+	newPeerRef, err := common.CreatePeerRefFromSecret(newSecret)
+	if err != nil {
+		return false, fmt.Errorf("failed to derive PeerRef from new secret: %w", err)
+	}
+	if !reflect.DeepEqual(oldPeerRef, newPeerRef) {
+		return true, nil
+	}
+	if !reflect.DeepEqual(oldSecret.Data[common.SecretDataKey], newSecret.Data[common.SecretDataKey]) {
+		return true, nil
+	}
+	if !reflect.DeepEqual(oldSecret.Data[common.SecretOriginKey], newSecret.Data[common.SecretOriginKey]) {
+		return true, nil
+	}
+	return false, nil
+}
+
+// Reconcile re-derives the PeerRef for the Source/Internal secret named in
+// req and re-runs CreateDestinationSecret against every MirrorPeer cluster
+// whose PeerRef matches, so a rotated payload reaches every peer without a
+// deep comparison living in the caller.
+func (r *MirrorPeerSecretReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Logger.WithValues("Secret", req.NamespacedName)
+
+	var sourceSecret corev1.Secret
+	if err := r.HubClient.Get(ctx, req.NamespacedName, &sourceSecret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get secret %q: %w", req.NamespacedName, err)
+	}
+
+	peerRef, err := common.CreatePeerRefFromSecret(&sourceSecret)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to derive PeerRef from secret %q: %w", req.NamespacedName, err)
+	}
+
+	mirrorPeers, err := common.FetchAllMirrorPeers(ctx, r.HubClient)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to fetch MirrorPeers: %w", err)
+	}
+
+	for _, mirrorPeer := range mirrorPeers {
+		for _, peer := range mirrorPeer.Spec.Items {
+			if !reflect.DeepEqual(peer, multiclusterv1alpha1.PeerRef{
+				ClusterName:       peerRef.ClusterName,
+				StorageClusterRef: peerRef.StorageClusterRef,
+			}) {
+				continue
+			}
+			if err := r.propagateToPeers(ctx, mirrorPeer, peerRef, &sourceSecret); err != nil {
+				logger.Error(err, "failed to propagate rotated secret to peers", "MirrorPeer", mirrorPeer.Name)
+				return ctrl.Result{}, err
+			}
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// propagateToPeers recreates the Destination secret on every peer cluster
+// of mirrorPeer other than the one the rotated secret originated from.
+func (r *MirrorPeerSecretReconciler) propagateToPeers(ctx context.Context, mirrorPeer multiclusterv1alpha1.MirrorPeer, sourcePeerRef multiclusterv1alpha1.PeerRef, sourceSecret *corev1.Secret) error {
+	for _, peer := range mirrorPeer.Spec.Items {
+		if reflect.DeepEqual(peer, multiclusterv1alpha1.PeerRef{
+			ClusterName:       sourcePeerRef.ClusterName,
+			StorageClusterRef: sourcePeerRef.StorageClusterRef,
+		}) {
+			continue
+		}
+
+		destSecretName, err := common.ResolveSecretName(peer, sourcePeerRef, mirrorPeer)
+		if err != nil {
+			return fmt.Errorf("failed to resolve destination secret name for cluster %q: %w", peer.ClusterName, err)
+		}
+		r.flagStaleHashedSecret(ctx, mirrorPeer, peer, sourcePeerRef, destSecretName)
+
+		destSecretKey := types.NamespacedName{
+			Name:      destSecretName,
+			Namespace: peer.ClusterName,
+		}
+		destSecret := common.CreateDestinationSecret(
+			destSecretKey,
+			types.NamespacedName{Namespace: sourcePeerRef.StorageClusterRef.Namespace, Name: sourcePeerRef.StorageClusterRef.Name},
+			sourceSecret.Data[common.SecretDataKey],
+			string(sourceSecret.Data[common.SecretOriginKey]),
+		)
+
+		var existing corev1.Secret
+		err = r.HubClient.Get(ctx, destSecretKey, &existing)
+		switch {
+		case apierrors.IsNotFound(err):
+			if err := r.HubClient.Create(ctx, destSecret); err != nil {
+				return fmt.Errorf("failed to create destination secret %q: %w", destSecretKey, err)
+			}
+		case err != nil:
+			return fmt.Errorf("failed to get destination secret %q: %w", destSecretKey, err)
+		default:
+			existing.Data = destSecret.Data
+			existing.Annotations = destSecret.Annotations
+			if err := r.HubClient.Update(ctx, &existing); err != nil {
+				return fmt.Errorf("failed to update destination secret %q: %w", destSecretKey, err)
+			}
+		}
+	}
+	return nil
+}
+
+// flagStaleHashedSecret checks, when resolvedName came from a
+// SecretNameOverrideAnnotationKey override, whether the previous
+// deterministically-hashed secret for peer still exists alongside it. If
+// so it emits an event on mirrorPeer pointing at the stale secret, so
+// cleanup of the old name can be scripted instead of left to rot silently.
+func (r *MirrorPeerSecretReconciler) flagStaleHashedSecret(ctx context.Context, mirrorPeer multiclusterv1alpha1.MirrorPeer, peer, sourcePeerRef multiclusterv1alpha1.PeerRef, resolvedName string) {
+	hashedName := common.HashedSecretName(peer, sourcePeerRef)
+	if hashedName == resolvedName || r.Recorder == nil {
+		return
+	}
+
+	var staleSecret corev1.Secret
+	err := r.HubClient.Get(ctx, types.NamespacedName{Name: hashedName, Namespace: peer.ClusterName}, &staleSecret)
+	if err != nil {
+		return
+	}
+
+	r.Recorder.Eventf(&mirrorPeer, corev1.EventTypeWarning, "StaleHashedSecret",
+		"cluster %q has migrated to secret-name-override %q; the previously hashed secret %q/%q is now unused and can be cleaned up",
+		peer.ClusterName, resolvedName, peer.ClusterName, hashedName)
+}
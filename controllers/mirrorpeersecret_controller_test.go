@@ -0,0 +1,85 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/red-hat-storage/odf-multicluster-orchestrator/controllers/common"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func internalSecret(namespace, storageClusterNamespace, storageClusterName, secretData, secretOrigin string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-secret",
+			Namespace: namespace,
+			Labels: map[string]string{
+				common.SecretLabelTypeKey: string(common.SourceLabel),
+			},
+		},
+		Data: map[string][]byte{
+			common.NamespaceKey:          []byte(storageClusterNamespace),
+			common.StorageClusterNameKey: []byte(storageClusterName),
+			common.SecretDataKey:         []byte(secretData),
+			common.SecretOriginKey:       []byte(secretOrigin),
+		},
+	}
+}
+
+func TestUpdateInternalSecret(t *testing.T) {
+	base := internalSecret("cluster-a", "openshift-storage", "ocs-storagecluster", "payload-v1", common.RookOrigin)
+
+	tests := []struct {
+		name    string
+		mutate  func(*corev1.Secret)
+		want    bool
+		wantErr bool
+	}{
+		{
+			name:   "identical secret does not need propagation",
+			mutate: func(s *corev1.Secret) {},
+			want:   false,
+		},
+		{
+			name:   "rotated payload needs propagation",
+			mutate: func(s *corev1.Secret) { s.Data[common.SecretDataKey] = []byte("payload-v2") },
+			want:   true,
+		},
+		{
+			name:   "changed origin needs propagation",
+			mutate: func(s *corev1.Secret) { s.Data[common.SecretOriginKey] = []byte(common.S3Origin) },
+			want:   true,
+		},
+		{
+			name:   "changed PeerRef needs propagation",
+			mutate: func(s *corev1.Secret) { s.Data[common.StorageClusterNameKey] = []byte("other-storagecluster") },
+			want:   true,
+		},
+		{
+			name:    "new secret is no longer a valid internal secret",
+			mutate:  func(s *corev1.Secret) { delete(s.Data, common.SecretDataKey) },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newSecret := base.DeepCopy()
+			tt.mutate(newSecret)
+
+			changed, err := updateInternalSecret(base, newSecret)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if changed != tt.want {
+				t.Errorf("updateInternalSecret() = %v, want %v", changed, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	"github.com/red-hat-storage/odf-multicluster-orchestrator/controllers/common"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// resolvedSecretNameSuffix names the derived secret RamenS3ProfileSecretReconciler
+// writes the plaintext AWS credentials Resolver returns into. The original
+// AWS_SECRETS_MANAGER-origin secret only ever carries the non-sensitive S3
+// profile fields and the credential reference annotation; it never itself
+// holds a resolved AWS key.
+const resolvedSecretNameSuffix = "-resolved"
+
+// RamenS3ProfileSecretReconciler is the ramen S3 profile writer's
+// AWS_SECRETS_MANAGER integration point: it watches S3 profile secrets that
+// reference AWS Secrets Manager instead of embedding static keys, resolves
+// them via Resolver at reconcile time, and writes the resolved S3Secret map
+// into a separate, owned "<name>-resolved" secret so the rest of the ramen
+// S3 profile handling keeps reading plain AWS_ACCESS_KEY_ID /
+// AWS_SECRET_ACCESS_KEY data exactly as it does for statically-provisioned
+// S3 profiles, without the reference secret itself ever persisting
+// plaintext keys.
+type RamenS3ProfileSecretReconciler struct {
+	HubClient client.Client
+	Scheme    *runtime.Scheme
+	Logger    logr.Logger
+	Resolver  *common.AWSSecretsManagerResolver
+}
+
+// SetupWithManager sets up the controller with the Manager, restricting it
+// to secrets whose SecretOriginKey is S3OriginAWSSecretsManager.
+func (r *RamenS3ProfileSecretReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Secret{}).
+		WithEventFilter(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			secret, ok := obj.(*corev1.Secret)
+			return ok && string(secret.Data[common.SecretOriginKey]) == common.S3OriginAWSSecretsManager
+		})).
+		Complete(r)
+}
+
+// Reconcile resolves the AWSSecretsManagerCredentials referenced by the
+// secret named in req and writes the resolved AWS credentials into a
+// separate, owned "<name>-resolved" secret, so the reference secret named
+// in req never itself carries plaintext AWS keys. If resolution fails, any
+// previously-written resolved secret is deleted rather than left stale.
+func (r *RamenS3ProfileSecretReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Logger.WithValues("Secret", req.NamespacedName)
+
+	var secret corev1.Secret
+	if err := r.HubClient.Get(ctx, req.NamespacedName, &secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get secret %q: %w", req.NamespacedName, err)
+	}
+
+	origin := string(secret.Data[common.SecretOriginKey])
+	if origin != common.S3OriginAWSSecretsManager {
+		return ctrl.Result{}, nil
+	}
+
+	resolvedKey := client.ObjectKey{Namespace: secret.Namespace, Name: secret.Name + resolvedSecretNameSuffix}
+
+	creds, err := common.ParseAWSSecretsManagerCredentials(secret.Annotations)
+	if err != nil {
+		r.deleteResolvedSecret(ctx, resolvedKey)
+		return ctrl.Result{}, fmt.Errorf("failed to parse AWS Secrets Manager credentials for secret %q: %w", req.NamespacedName, err)
+	}
+	if !common.ValidateS3Secret(secret.Data, origin, creds) {
+		r.deleteResolvedSecret(ctx, resolvedKey)
+		return ctrl.Result{}, fmt.Errorf("secret %q is not a valid AWS Secrets Manager S3 profile reference", req.NamespacedName)
+	}
+
+	resolved, err := r.Resolver.Resolve(ctx, secret.Data, creds)
+	if err != nil {
+		// Don't leave a previously-resolved secret carrying credentials
+		// that may since have been rotated or revoked in Secrets Manager.
+		r.deleteResolvedSecret(ctx, resolvedKey)
+		return ctrl.Result{}, fmt.Errorf("failed to resolve AWS Secrets Manager credentials for secret %q: %w", req.NamespacedName, err)
+	}
+	resolved[common.SecretOriginKey] = []byte(common.S3Origin)
+
+	if err := r.writeResolvedSecret(ctx, resolvedKey, &secret, resolved); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	logger.Info("resolved AWS Secrets Manager credentials for S3 profile secret", "ResolvedSecret", resolvedKey)
+
+	// Resolved credentials expire with AWSSecretsManagerCacheTTL; requeue
+	// so a rotated Secrets Manager value is picked up even without a
+	// watch event on this secret.
+	return ctrl.Result{RequeueAfter: common.AWSSecretsManagerCacheTTL}, nil
+}
+
+// writeResolvedSecret creates or updates the owned "<name>-resolved" secret
+// at resolvedKey with data, leaving source untouched.
+func (r *RamenS3ProfileSecretReconciler) writeResolvedSecret(ctx context.Context, resolvedKey client.ObjectKey, source *corev1.Secret, data map[string][]byte) error {
+	var existing corev1.Secret
+	err := r.HubClient.Get(ctx, resolvedKey, &existing)
+	switch {
+	case apierrors.IsNotFound(err):
+		resolvedSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      resolvedKey.Name,
+				Namespace: resolvedKey.Namespace,
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: data,
+		}
+		if err := controllerutil.SetControllerReference(source, resolvedSecret, r.Scheme); err != nil {
+			return fmt.Errorf("failed to set owner reference on secret %q: %w", resolvedKey, err)
+		}
+		if err := r.HubClient.Create(ctx, resolvedSecret); err != nil {
+			return fmt.Errorf("failed to create resolved secret %q: %w", resolvedKey, err)
+		}
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to get resolved secret %q: %w", resolvedKey, err)
+	}
+
+	if reflect.DeepEqual(existing.Data, data) {
+		return nil
+	}
+	existing.Data = data
+	if err := r.HubClient.Update(ctx, &existing); err != nil {
+		return fmt.Errorf("failed to update resolved secret %q: %w", resolvedKey, err)
+	}
+	return nil
+}
+
+// deleteResolvedSecret removes a previously-written resolved secret, e.g.
+// when Resolve fails, so a stale plaintext credential set is never left
+// behind. Errors are logged rather than returned: the caller is already
+// about to return a resolve error, and requeues will retry the delete too.
+func (r *RamenS3ProfileSecretReconciler) deleteResolvedSecret(ctx context.Context, resolvedKey client.ObjectKey) {
+	var existing corev1.Secret
+	if err := r.HubClient.Get(ctx, resolvedKey, &existing); err != nil {
+		return
+	}
+	if err := r.HubClient.Delete(ctx, &existing); err != nil && !apierrors.IsNotFound(err) {
+		r.Logger.Error(err, "failed to delete stale resolved secret", "ResolvedSecret", resolvedKey)
+	}
+}
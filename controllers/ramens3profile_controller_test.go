@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/red-hat-storage/odf-multicluster-orchestrator/controllers/common"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func reconcileRequest(secret *corev1.Secret) ctrl.Request {
+	return ctrl.Request{NamespacedName: client.ObjectKeyFromObject(secret)}
+}
+
+func TestRamenS3ProfileReconcileIgnoresNonAWSSecretsManagerOrigin(t *testing.T) {
+	scheme := newTestScheme(t)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "s3-profile", Namespace: "ns"},
+		Data:       map[string][]byte{common.SecretOriginKey: []byte(common.S3Origin)},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	r := &RamenS3ProfileSecretReconciler{HubClient: fakeClient, Scheme: scheme, Logger: logr.Discard()}
+
+	if _, err := r.Reconcile(context.Background(), reconcileRequest(secret)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var resolved corev1.Secret
+	err := fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "ns", Name: "s3-profile" + resolvedSecretNameSuffix}, &resolved)
+	if !apierrors.IsNotFound(err) {
+		t.Fatalf("expected no resolved secret to be created for a static S3 profile, got err=%v", err)
+	}
+}
+
+func TestRamenS3ProfileReconcileDeletesStaleResolvedSecretOnValidationFailure(t *testing.T) {
+	scheme := newTestScheme(t)
+
+	// Missing the required S3 profile fields (s3ProfileName, s3Bucket, ...),
+	// so ValidateS3Secret fails before Resolver is ever consulted.
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "s3-profile", Namespace: "ns"},
+		Data:       map[string][]byte{common.SecretOriginKey: []byte(common.S3OriginAWSSecretsManager)},
+	}
+
+	staleResolved := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "s3-profile" + resolvedSecretNameSuffix, Namespace: "ns"},
+		Data:       map[string][]byte{common.AwsAccessKeyId: []byte("stale")},
+	}
+	if err := controllerutil.SetControllerReference(secret, staleResolved, scheme); err != nil {
+		t.Fatalf("failed to set owner reference: %v", err)
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret, staleResolved).Build()
+	r := &RamenS3ProfileSecretReconciler{HubClient: fakeClient, Scheme: scheme, Logger: logr.Discard()}
+
+	if _, err := r.Reconcile(context.Background(), reconcileRequest(secret)); err == nil {
+		t.Fatal("expected an error for an invalid AWS Secrets Manager S3 profile reference")
+	}
+
+	var resolved corev1.Secret
+	err := fakeClient.Get(context.Background(), client.ObjectKeyFromObject(staleResolved), &resolved)
+	if !apierrors.IsNotFound(err) {
+		t.Fatalf("expected the stale resolved secret to be deleted, got err=%v", err)
+	}
+}
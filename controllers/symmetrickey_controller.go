@@ -0,0 +1,171 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	multiclusterv1alpha1 "github.com/red-hat-storage/odf-multicluster-orchestrator/api/v1alpha1"
+	"github.com/red-hat-storage/odf-multicluster-orchestrator/controllers/common"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// SymmetricKeyReconciler owns generation and periodic rotation of the
+// shared symmetric material used inside a MirrorPeerSecret (e.g. the
+// encryption/HMAC key protecting SecretDataKey). It reconciles a single
+// target secret per MirrorPeer via common.SecretHelper, and on rotation
+// flags every downstream Source/Destination secret belonging to that
+// MirrorPeer for re-validation by re-annotating it, so operators can see
+// which secrets still need to be re-wrapped under the new material.
+// Actually re-wrapping SecretDataKey is not implemented here.
+type SymmetricKeyReconciler struct {
+	HubClient client.Client
+	Scheme    *runtime.Scheme
+	Logger    logr.Logger
+	Helper    common.SecretHelper
+
+	// SecretNamespace is the namespace the operator itself runs in. The
+	// symmetric key secret is namespaced, but MirrorPeer is
+	// cluster-scoped, so the secret cannot simply reuse the MirrorPeer's
+	// (empty) namespace.
+	SecretNamespace string
+}
+
+// Reconcile ensures the symmetric key secret for the MirrorPeer named in
+// req exists, is owned by that MirrorPeer, and is regenerated whenever
+// Helper.Observe reports it needs rotation.
+func (r *SymmetricKeyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var mirrorPeer multiclusterv1alpha1.MirrorPeer
+	if err := r.HubClient.Get(ctx, client.ObjectKey{Name: req.Name}, &mirrorPeer); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get MirrorPeer %q: %w", req.Name, err)
+	}
+
+	secretKey := types.NamespacedName{Namespace: r.SecretNamespace, Name: mirrorPeer.Name}
+	logger := r.Logger.WithValues("Secret", secretKey, "MirrorPeer", mirrorPeer.Name)
+
+	var existing corev1.Secret
+	err := r.HubClient.Get(ctx, secretKey, &existing)
+	switch {
+	case apierrors.IsNotFound(err):
+		return r.generate(ctx, secretKey, &mirrorPeer, nil)
+	case err != nil:
+		return ctrl.Result{}, fmt.Errorf("failed to get secret %q: %w", secretKey, err)
+	}
+
+	// Observe before mutating: a helper that flips straight from
+	// NeedsRotation to Valid on the next call (instead of reporting the
+	// rotation was applied) must not cause us to loop forever re-creating
+	// the same secret.
+	state := r.Helper.Observe(&existing)
+	if state == common.SecretObserveStateValid {
+		return ctrl.Result{}, nil
+	}
+
+	logger.Info("rotating symmetric key secret", "reason", state)
+	if _, err := r.generate(ctx, secretKey, &mirrorPeer, &existing); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.enqueueDownstreamSecrets(ctx, &mirrorPeer); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to enqueue downstream secrets after rotation: %w", err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// generate creates or updates the symmetric key secret at secretKey using
+// r.Helper, owned by mirrorPeer. existing is nil when the secret does not
+// exist yet.
+func (r *SymmetricKeyReconciler) generate(ctx context.Context, secretKey types.NamespacedName, mirrorPeer *multiclusterv1alpha1.MirrorPeer, existing *corev1.Secret) (ctrl.Result, error) {
+	generated, err := r.Helper.Generate(secretKey)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to generate secret %q: %w", secretKey, err)
+	}
+	if err := controllerutil.SetControllerReference(mirrorPeer, generated, r.Scheme); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to set owner reference on secret %q: %w", secretKey, err)
+	}
+
+	if existing == nil {
+		if err := r.HubClient.Create(ctx, generated); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to create secret %q: %w", secretKey, err)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	existing.Data = generated.Data
+	existing.Annotations = generated.Annotations
+	existing.OwnerReferences = generated.OwnerReferences
+	if err := r.HubClient.Update(ctx, existing); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update secret %q: %w", secretKey, err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// enqueueDownstreamSecrets touches the secret-data-hash annotation on every
+// Source/Destination secret whose derived PeerRef belongs to mirrorPeer, so
+// the existing SourceOrDestinationPredicate fires and ValidateInternalSecret
+// re-runs. No Source/Destination secret carries CreatedByLabelKey, so this
+// lists every secret and filters down to Source/Destination secrets
+// belonging to mirrorPeer itself; secrets belonging to other MirrorPeers are
+// left untouched.
+func (r *SymmetricKeyReconciler) enqueueDownstreamSecrets(ctx context.Context, mirrorPeer *multiclusterv1alpha1.MirrorPeer) error {
+	var secretList corev1.SecretList
+	if err := r.HubClient.List(ctx, &secretList); err != nil {
+		return fmt.Errorf("failed to list secrets owned by %q: %w", mirrorPeer.Name, err)
+	}
+	// Mixing in the rotation time makes the annotation differ on every
+	// rotation, even when SecretDataKey itself is unchanged.
+	rotatedAt := time.Now().UTC().Format(time.RFC3339Nano)
+
+	for i := range secretList.Items {
+		secret := &secretList.Items[i]
+		if !common.IsSecretSource(secret) && !common.IsSecretDestination(secret) {
+			continue
+		}
+
+		peerRef, err := common.CreatePeerRefFromSecret(secret)
+		if err != nil || !mirrorPeerHasPeerRef(mirrorPeer, peerRef) {
+			continue
+		}
+
+		if secret.Annotations == nil {
+			secret.Annotations = map[string]string{}
+		}
+		secret.Annotations[common.SecretDataHashAnnotationKey] = common.CreateUniqueName(
+			string(secret.Data[common.SecretDataKey]), mirrorPeer.Name, rotatedAt)
+		if err := r.HubClient.Update(ctx, secret); err != nil {
+			return fmt.Errorf("failed to re-annotate secret %q/%q: %w", secret.Namespace, secret.Name, err)
+		}
+	}
+	return nil
+}
+
+// mirrorPeerHasPeerRef reports whether peerRef is one of mirrorPeer's own
+// PeerRefs.
+func mirrorPeerHasPeerRef(mirrorPeer *multiclusterv1alpha1.MirrorPeer, peerRef multiclusterv1alpha1.PeerRef) bool {
+	for _, peer := range mirrorPeer.Spec.Items {
+		if reflect.DeepEqual(peer, peerRef) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SymmetricKeyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&multiclusterv1alpha1.MirrorPeer{}).
+		Owns(&corev1.Secret{}).
+		Complete(r)
+}
@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	multiclusterv1alpha1 "github.com/red-hat-storage/odf-multicluster-orchestrator/api/v1alpha1"
+	"github.com/red-hat-storage/odf-multicluster-orchestrator/controllers/common"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newTestScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add corev1 to scheme: %v", err)
+	}
+	if err := multiclusterv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add multiclusterv1alpha1 to scheme: %v", err)
+	}
+	return scheme
+}
+
+func ownedInternalSecret(name string, label common.SecretLabelType, storageClusterNamespace, storageClusterName string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "other-cluster",
+			Labels: map[string]string{
+				common.SecretLabelTypeKey: string(label),
+			},
+		},
+		Data: map[string][]byte{
+			common.NamespaceKey:          []byte(storageClusterNamespace),
+			common.StorageClusterNameKey: []byte(storageClusterName),
+			common.SecretDataKey:         []byte("payload"),
+			common.SecretOriginKey:       []byte(common.RookOrigin),
+		},
+	}
+}
+
+func TestEnqueueDownstreamSecretsOnlyTouchesOwnPeerRefs(t *testing.T) {
+	scheme := newTestScheme(t)
+
+	mirrorPeer := &multiclusterv1alpha1.MirrorPeer{
+		ObjectMeta: metav1.ObjectMeta{Name: "mp-rotated"},
+		Spec: multiclusterv1alpha1.MirrorPeerSpec{
+			Items: []multiclusterv1alpha1.PeerRef{
+				{
+					ClusterName: "other-cluster",
+					StorageClusterRef: multiclusterv1alpha1.StorageClusterRef{
+						Namespace: "openshift-storage",
+						Name:      "ocs-storagecluster",
+					},
+				},
+			},
+		},
+	}
+
+	ownSecret := ownedInternalSecret("own-secret", common.SourceLabel, "openshift-storage", "ocs-storagecluster")
+	otherMirrorPeerSecret := ownedInternalSecret("unrelated-secret", common.SourceLabel, "openshift-storage", "some-other-storagecluster")
+	internalLabelSecret := ownedInternalSecret("internal-secret", common.InternalLabel, "openshift-storage", "ocs-storagecluster")
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(mirrorPeer, ownSecret, otherMirrorPeerSecret, internalLabelSecret).
+		Build()
+
+	r := &SymmetricKeyReconciler{HubClient: fakeClient, Logger: logr.Discard()}
+
+	if err := r.enqueueDownstreamSecrets(context.Background(), mirrorPeer); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got corev1.Secret
+	if err := fakeClient.Get(context.Background(), client.ObjectKeyFromObject(ownSecret), &got); err != nil {
+		t.Fatalf("failed to get own secret: %v", err)
+	}
+	if _, ok := got.Annotations[common.SecretDataHashAnnotationKey]; !ok {
+		t.Error("expected own secret to be re-annotated with SecretDataHashAnnotationKey")
+	}
+
+	var untouched corev1.Secret
+	if err := fakeClient.Get(context.Background(), client.ObjectKeyFromObject(otherMirrorPeerSecret), &untouched); err != nil {
+		t.Fatalf("failed to get unrelated secret: %v", err)
+	}
+	if _, ok := untouched.Annotations[common.SecretDataHashAnnotationKey]; ok {
+		t.Error("expected secret belonging to a different MirrorPeer to be left untouched")
+	}
+
+	var internal corev1.Secret
+	if err := fakeClient.Get(context.Background(), client.ObjectKeyFromObject(internalLabelSecret), &internal); err != nil {
+		t.Fatalf("failed to get internal-label secret: %v", err)
+	}
+	if _, ok := internal.Annotations[common.SecretDataHashAnnotationKey]; ok {
+		t.Error("expected an INTERNAL-labeled secret to be left untouched")
+	}
+}